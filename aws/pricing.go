@@ -0,0 +1,145 @@
+// aws/pricing.go
+package aws
+
+import (
+    "context"
+    "encoding/json"
+    "strconv"
+
+    awsconfig "github.com/aws/aws-sdk-go-v2/config"
+    awspricing "github.com/aws/aws-sdk-go-v2/service/pricing"
+    "github.com/aws/aws-sdk-go-v2/service/pricing/types"
+
+    "github.com/asifkhanbk/cost-estimator/pricing"
+)
+
+// The AWS Price List Query API is only served out of us-east-1, regardless
+// of which region the priced resource actually lives in.
+const queryAPIRegion = "us-east-1"
+
+// serviceCodes maps the pricing.PricingEngine "service" argument (as set by
+// AWSResourceTypePricingMap entries) to the AWS Price List ServiceCode it
+// should be queried under.
+var serviceCodes = map[string]string{
+    "EC2":          "AmazonEC2",
+    "RDS":          "AmazonRDS",
+    "S3":           "AmazonS3",
+    "ELB":          "AWSELB",
+    "EKS":          "AmazonEKS",
+    "EBS":          "AmazonEC2",
+    "Lambda":       "AWSLambda",
+    "CloudFront":   "AmazonCloudFront",
+    "DataTransfer": "AWSDataTransfer",
+    "NATGateway":   "AmazonEC2",
+}
+
+// skuFilterFields maps a service to the AWS Price List product attribute
+// that our sku string should be matched against: EC2/RDS products are
+// keyed by instanceType, but EBS volumes are keyed by volumeApiName (e.g.
+// "gp3") and ELB products by loadBalancerType (e.g. "application"), neither
+// of which has an instanceType attribute at all. Services not listed here
+// default to instanceType.
+var skuFilterFields = map[string]string{
+    "EC2": "instanceType",
+    "RDS": "instanceType",
+    "EBS": "volumeApiName",
+    "ELB": "loadBalancerType",
+}
+
+func skuFilterField(service string) string {
+    if field, ok := skuFilterFields[service]; ok {
+        return field
+    }
+    return "instanceType"
+}
+
+// NewAWSPricing returns a pricing.PricingEngine backed by the AWS Price List
+// Query API (pricing.GetProducts).
+func NewAWSPricing() pricing.PricingEngine {
+    cfg, err := awsconfig.LoadDefaultConfig(context.Background(), awsconfig.WithRegion(queryAPIRegion))
+    if err != nil {
+        return &awsPricing{client: nil}
+    }
+    return &awsPricing{client: awspricing.NewFromConfig(cfg)}
+}
+
+type awsPricing struct {
+    client *awspricing.Client
+}
+
+func (a *awsPricing) FetchPrice(service, region, sku string) (float64, string, bool) {
+    if a.client == nil {
+        return 0, "", false
+    }
+
+    serviceCode, ok := serviceCodes[service]
+    if !ok {
+        serviceCode = service
+    }
+
+    filters := []types.Filter{
+        {Field: strPtr("ServiceCode"), Type: types.FilterTypeTermMatch, Value: strPtr(serviceCode)},
+    }
+    if region != "" {
+        filters = append(filters, types.Filter{
+            Field: strPtr("regionCode"), Type: types.FilterTypeTermMatch, Value: strPtr(region),
+        })
+    }
+    if sku != "" {
+        filters = append(filters, types.Filter{
+            Field: strPtr(skuFilterField(service)), Type: types.FilterTypeEquals, Value: strPtr(sku),
+        })
+    }
+
+    out, err := a.client.GetProducts(context.Background(), &awspricing.GetProductsInput{
+        ServiceCode: strPtr(serviceCode),
+        Filters:     filters,
+        MaxResults:  int32Ptr(20),
+    })
+    if err != nil {
+        return 0, "", false
+    }
+
+    for _, raw := range out.PriceList {
+        cost, unit, found := parsePriceListEntry(raw)
+        if found {
+            return cost, unit, true
+        }
+    }
+    return 0, "", false
+}
+
+// priceListEntry mirrors the subset of the AWS Price List JSON document
+// (https://.../offers/v1.0/aws/<ServiceCode>/current/<region>/index.json)
+// that GetProducts returns per item, under "terms.OnDemand".
+type priceListEntry struct {
+    Terms struct {
+        OnDemand map[string]struct {
+            PriceDimensions map[string]struct {
+                Unit         string `json:"unit"`
+                PricePerUnit struct {
+                    USD string `json:"USD"`
+                } `json:"pricePerUnit"`
+            } `json:"priceDimensions"`
+        } `json:"OnDemand"`
+    } `json:"terms"`
+}
+
+func parsePriceListEntry(raw string) (float64, string, bool) {
+    var entry priceListEntry
+    if err := json.Unmarshal([]byte(raw), &entry); err != nil {
+        return 0, "", false
+    }
+    for _, term := range entry.Terms.OnDemand {
+        for _, dim := range term.PriceDimensions {
+            cost, err := strconv.ParseFloat(dim.PricePerUnit.USD, 64)
+            if err == nil && cost > 0 {
+                return cost, dim.Unit, true
+            }
+        }
+    }
+    return 0, "", false
+}
+
+func strPtr(s string) *string { return &s }
+func int32Ptr(i int32) *int32 { return &i }
@@ -21,21 +21,35 @@ type azurePricing struct {
     client *http.Client
 }
 
+// hoursInTerm maps a reservation term to the number of hours it covers,
+// used to amortize the term's upfront price into a monthly-equivalent
+// hourly rate.
+var hoursInTerm = map[string]float64{
+    "1 Year":  730 * 12,
+    "3 Years": 730 * 12 * 3,
+}
+
 func (a *azurePricing) FetchPrice(service, region, sku string) (float64, string, bool) {
+    return a.FetchPriceWithOptions(service, region, sku, pricing.PAYG)
+}
+
+func (a *azurePricing) FetchPriceWithOptions(service, region, sku string, opts pricing.PricingOptions) (float64, string, bool) {
     base := "https://prices.azure.com/api/retail/prices"
+    extra := odataExtra(opts)
+
     var filters []string
     if region != "" && sku != "" {
         filters = append(filters,
-            fmt.Sprintf("serviceName eq '%s' and armRegionName eq '%s' and (skuName eq '%s' or armSkuName eq '%s')",
-                service, region, sku, sku),
+            fmt.Sprintf("serviceName eq '%s' and armRegionName eq '%s' and (skuName eq '%s' or armSkuName eq '%s')%s",
+                service, region, sku, sku, extra),
         )
     }
     if region != "" {
         filters = append(filters,
-            fmt.Sprintf("serviceName eq '%s' and armRegionName eq '%s'", service, region),
+            fmt.Sprintf("serviceName eq '%s' and armRegionName eq '%s'%s", service, region, extra),
         )
     }
-    filters = append(filters, fmt.Sprintf("serviceName eq '%s'", service))
+    filters = append(filters, fmt.Sprintf("serviceName eq '%s'%s", service, extra))
 
     for _, filter := range filters {
         urlStr := base + "?$filter=" + url.QueryEscape(filter)
@@ -47,13 +61,15 @@ func (a *azurePricing) FetchPrice(service, region, sku string) (float64, string,
             defer resp.Body.Close()
 
             var out struct {
-                Items        []struct {
-                    RetailPrice   float64 `json:"retailPrice"`
-                    UnitOfMeasure string  `json:"unitOfMeasure"`
-                    MeterName     string  `json:"meterName"`
-                    ArmSkuName    string  `json:"armSkuName"`
-                    SkuName       string  `json:"skuName"`
-                    ArmRegionName string  `json:"armRegionName"`
+                Items []struct {
+                    RetailPrice     float64 `json:"retailPrice"`
+                    UnitOfMeasure   string  `json:"unitOfMeasure"`
+                    MeterName       string  `json:"meterName"`
+                    ArmSkuName      string  `json:"armSkuName"`
+                    SkuName         string  `json:"skuName"`
+                    ArmRegionName   string  `json:"armRegionName"`
+                    ReservationTerm string  `json:"reservationTerm"`
+                    PriceType       string  `json:"type"`
                 } `json:"Items"`
                 NextPageLink string `json:"NextPageLink"`
             }
@@ -76,17 +92,21 @@ func (a *azurePricing) FetchPrice(service, region, sku string) (float64, string,
             }
 
             for _, item := range out.Items {
-                if item.RetailPrice > 0 {
-                    if sku != "" && (item.ArmSkuName == sku || item.SkuName == sku || strings.Contains(item.MeterName, sku)) {
-                        return item.RetailPrice, item.UnitOfMeasure, true
-                    }
-                    if strings.Contains(strings.ToLower(item.UnitOfMeasure), "operation") ||
-                        strings.Contains(strings.ToLower(item.MeterName), "operation") {
-                        return item.RetailPrice, item.UnitOfMeasure, true
-                    }
-                    if sku == "" {
-                        return item.RetailPrice, item.UnitOfMeasure, true
-                    }
+                if item.RetailPrice <= 0 {
+                    continue
+                }
+                if opts.Spot && !strings.Contains(strings.ToLower(item.MeterName), "spot") {
+                    continue
+                }
+                if sku != "" && (item.ArmSkuName == sku || item.SkuName == sku || strings.Contains(item.MeterName, sku)) {
+                    return amortize(item.RetailPrice, item.UnitOfMeasure, opts)
+                }
+                if strings.Contains(strings.ToLower(item.UnitOfMeasure), "operation") ||
+                    strings.Contains(strings.ToLower(item.MeterName), "operation") {
+                    return amortize(item.RetailPrice, item.UnitOfMeasure, opts)
+                }
+                if sku == "" {
+                    return amortize(item.RetailPrice, item.UnitOfMeasure, opts)
                 }
             }
             urlStr = out.NextPageLink
@@ -94,3 +114,37 @@ func (a *azurePricing) FetchPrice(service, region, sku string) (float64, string,
     }
     return 0, "", false
 }
+
+// odataExtra builds the additional OData filter clauses the Retail Prices
+// API needs to scope results to a price type and reservation term. Spot
+// adds no clause here; spot meters are picked out by meter name in
+// FetchPriceWithOptions.
+func odataExtra(opts pricing.PricingOptions) string {
+    var b strings.Builder
+    switch {
+    case opts.PriceType == "Reservation":
+        b.WriteString(" and priceType eq 'Reservation'")
+        if opts.ReservationTerm != "" {
+            fmt.Fprintf(&b, " and reservationTerm eq '%s'", opts.ReservationTerm)
+        }
+    case opts.PriceType != "":
+        fmt.Fprintf(&b, " and priceType eq '%s'", opts.PriceType)
+    default:
+        b.WriteString(" and priceType eq 'Consumption'")
+    }
+    return b.String()
+}
+
+// amortize spreads a reservation's upfront retailPrice evenly across its
+// term, returning an effective hourly rate so the caller's existing
+// "unitCost * 730 * quantity" monthly math keeps working unchanged.
+func amortize(retailPrice float64, unit string, opts pricing.PricingOptions) (float64, string, bool) {
+    if opts.PriceType != "Reservation" {
+        return retailPrice, unit, true
+    }
+    hours, ok := hoursInTerm[opts.ReservationTerm]
+    if !ok || !strings.Contains(strings.ToLower(unit), "year") {
+        return retailPrice, unit, true
+    }
+    return retailPrice / hours, "1 Hour", true
+}
@@ -0,0 +1,233 @@
+// cache/cache.go
+package cache
+
+import (
+    "container/list"
+    "encoding/json"
+    "fmt"
+    "os"
+    "path/filepath"
+    "sync"
+    "time"
+
+    "github.com/asifkhanbk/cost-estimator/pricing"
+)
+
+// entry is a single cached (service, region, sku) price lookup.
+type entry struct {
+    UnitCost  float64   `json:"unit_cost"`
+    Unit      string    `json:"unit"`
+    FetchedAt time.Time `json:"fetched_at"`
+}
+
+func (e entry) expired(ttl time.Duration) bool {
+    return time.Since(e.FetchedAt) > ttl
+}
+
+func key(service, region, sku string) string {
+    return service + "|" + region + "|" + sku
+}
+
+// keyWithOptions gives pay-as-you-go lookups the same key as FetchPrice, so
+// prices cached by Warm also serve a PAYG estimate.
+func keyWithOptions(service, region, sku string, opts pricing.PricingOptions) string {
+    if opts == pricing.PAYG {
+        return key(service, region, sku)
+    }
+    return fmt.Sprintf("%s|%v", key(service, region, sku), opts)
+}
+
+// Stats tracks cache effectiveness for a run, printed at the end of
+// `estimate`.
+type Stats struct {
+    Hits   int
+    Misses int
+}
+
+const defaultLRUCap = 256
+
+// DefaultDir returns the on-disk cache directory, creating it if needed.
+func DefaultDir() (string, error) {
+    home, err := os.UserHomeDir()
+    if err != nil {
+        return "", err
+    }
+    dir := filepath.Join(home, ".cost-estimator", "cache")
+    if err := os.MkdirAll(dir, 0o755); err != nil {
+        return "", err
+    }
+    return dir, nil
+}
+
+func filePath(dir string) string {
+    return filepath.Join(dir, "prices.json")
+}
+
+// Engine wraps a pricing.PricingEngine with a TTL-bounded on-disk cache and
+// a bounded in-memory LRU in front of it, so a plan that references the
+// same VM size dozens of times only ever pays for one lookup.
+type Engine struct {
+    Stats Stats
+
+    inner   pricing.PricingEngine
+    dir     string
+    ttl     time.Duration
+    offline bool
+
+    mu      sync.Mutex
+    entries map[string]entry
+    lru     *list.List
+    lruPos  map[string]*list.Element
+}
+
+// New wraps inner with a persistent cache stored under dir, expiring
+// entries older than ttl. When offline is true, a miss returns
+// (0, "", false) instead of falling through to inner.
+func New(inner pricing.PricingEngine, dir string, ttl time.Duration, offline bool) (*Engine, error) {
+    e := &Engine{
+        inner:   inner,
+        dir:     dir,
+        ttl:     ttl,
+        offline: offline,
+        entries: map[string]entry{},
+        lru:     list.New(),
+        lruPos:  map[string]*list.Element{},
+    }
+    data, err := os.ReadFile(filePath(dir))
+    if err != nil {
+        if os.IsNotExist(err) {
+            return e, nil
+        }
+        return nil, err
+    }
+    if err := json.Unmarshal(data, &e.entries); err != nil {
+        return nil, err
+    }
+    // Seed the LRU from what was loaded so the eviction below (and in put)
+    // bounds the on-disk file across runs, not just this run's fresh
+    // fetches. e isn't shared yet, so no lock is needed here.
+    for k := range e.entries {
+        e.lruPos[k] = e.lru.PushFront(k)
+    }
+    e.evictLocked()
+    return e, nil
+}
+
+// Save persists the on-disk cache. Callers should call this once after a
+// run completes.
+func (e *Engine) Save() error {
+    e.mu.Lock()
+    defer e.mu.Unlock()
+    data, err := json.MarshalIndent(e.entries, "", "  ")
+    if err != nil {
+        return err
+    }
+    return os.WriteFile(filePath(e.dir), data, 0o644)
+}
+
+// FetchPrice satisfies pricing.PricingEngine, serving from the in-memory
+// LRU or on-disk cache when possible and only calling through to inner on a
+// cold or expired entry.
+func (e *Engine) FetchPrice(service, region, sku string) (float64, string, bool) {
+    return e.fetch(key(service, region, sku), func() (float64, string, bool) {
+        return e.inner.FetchPrice(service, region, sku)
+    })
+}
+
+// FetchPriceWithOptions satisfies pricing.OptionsPricingEngine, caching
+// each (service, region, sku, opts) combination independently since a
+// reservation or spot price is a different number than on-demand.
+func (e *Engine) FetchPriceWithOptions(service, region, sku string, opts pricing.PricingOptions) (float64, string, bool) {
+    return e.fetch(keyWithOptions(service, region, sku, opts), func() (float64, string, bool) {
+        return pricing.FetchPriceWithOptions(e.inner, service, region, sku, opts)
+    })
+}
+
+func (e *Engine) fetch(k string, miss func() (float64, string, bool)) (float64, string, bool) {
+    e.mu.Lock()
+    if ent, ok := e.entries[k]; ok && !ent.expired(e.ttl) {
+        e.touchLocked(k)
+        e.Stats.Hits++
+        e.mu.Unlock()
+        return ent.UnitCost, ent.Unit, true
+    }
+    e.mu.Unlock()
+
+    e.Stats.Misses++
+    if e.offline {
+        return 0, "", false
+    }
+
+    cost, unit, found := miss()
+    if found {
+        e.put(k, entry{UnitCost: cost, Unit: unit, FetchedAt: time.Now()})
+    }
+    return cost, unit, found
+}
+
+func (e *Engine) put(k string, v entry) {
+    e.mu.Lock()
+    defer e.mu.Unlock()
+    e.entries[k] = v
+    e.touchLocked(k)
+    e.evictLocked()
+}
+
+// evictLocked trims e.entries/e.lru down to defaultLRUCap, evicting the
+// least-recently-used entries first. Callers must hold e.mu.
+func (e *Engine) evictLocked() {
+    for e.lru.Len() > defaultLRUCap {
+        oldest := e.lru.Back()
+        if oldest == nil {
+            break
+        }
+        e.lru.Remove(oldest)
+        delete(e.lruPos, oldest.Value.(string))
+        delete(e.entries, oldest.Value.(string))
+    }
+}
+
+func (e *Engine) touchLocked(k string) {
+    if el, ok := e.lruPos[k]; ok {
+        e.lru.MoveToFront(el)
+        return
+    }
+    e.lruPos[k] = e.lru.PushFront(k)
+}
+
+// Purge removes every cached entry from disk and memory.
+func (e *Engine) Purge() error {
+    e.mu.Lock()
+    e.entries = map[string]entry{}
+    e.lru.Init()
+    e.lruPos = map[string]*list.Element{}
+    e.mu.Unlock()
+    return e.Save()
+}
+
+// Warm fetches and caches the pay-as-you-go price for every (service,
+// region, sku) triple in skus, using up to concurrency workers at a time.
+func (e *Engine) Warm(skus []SKURequest, concurrency int) {
+    if concurrency < 1 {
+        concurrency = 1
+    }
+    sem := make(chan struct{}, concurrency)
+    var wg sync.WaitGroup
+    for _, s := range skus {
+        wg.Add(1)
+        sem <- struct{}{}
+        go func(s SKURequest) {
+            defer wg.Done()
+            defer func() { <-sem }()
+            e.FetchPrice(s.Service, s.Region, s.SKU)
+        }(s)
+    }
+    wg.Wait()
+}
+
+// SKURequest identifies a single price point to prefetch via Warm.
+type SKURequest struct {
+    Service string
+    Region  string
+    SKU     string
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+    "fmt"
+    "os"
+
+    "github.com/spf13/cobra"
+
+    "github.com/asifkhanbk/cost-estimator/cache"
+)
+
+var cacheCmd = &cobra.Command{
+    Use:   "cache",
+    Short: "Inspect or manage the on-disk price cache",
+}
+
+var cachePurgeCmd = &cobra.Command{
+    Use:   "purge",
+    Short: "Delete every cached price",
+    Run:   runCachePurge,
+}
+
+var cacheWarmCmd = &cobra.Command{
+    Use:   "warm",
+    Short: "Prefetch prices for every SKU referenced by --plan",
+    Run:   runCacheWarm,
+}
+
+var warmConcurrency int
+
+func init() {
+    rootCmd.AddCommand(cacheCmd)
+    cacheCmd.AddCommand(cachePurgeCmd)
+    cacheCmd.AddCommand(cacheWarmCmd)
+    cacheWarmCmd.Flags().IntVar(&warmConcurrency, "concurrency", 8, "Number of concurrent price lookups while warming")
+}
+
+func runCachePurge(cmd *cobra.Command, args []string) {
+    engine, err := newCachedEngine(nil)
+    if err != nil {
+        fmt.Printf("❌ Failed to open cache: %v\n", err)
+        os.Exit(1)
+    }
+    if err := engine.Purge(); err != nil {
+        fmt.Printf("❌ Failed to purge cache: %v\n", err)
+        os.Exit(1)
+    }
+    fmt.Println("✅ Price cache purged")
+}
+
+func runCacheWarm(cmd *cobra.Command, args []string) {
+    if planFile == "" {
+        fmt.Println("❌ --plan is required to know which SKUs to warm")
+        os.Exit(1)
+    }
+
+    resources, vars, addrMap, clusterRegions, err := loadPlanResources(planFile)
+    if err != nil {
+        fmt.Printf("❌ %v\n", err)
+        os.Exit(1)
+    }
+
+    // Group the SKUs to prefetch by provider so each is warmed against its
+    // own cache-wrapped engine.
+    byProvider := map[string][]cache.SKURequest{}
+    for _, r := range resources {
+        def, found := pricingDefinitionFor(r.Type)
+        if !found {
+            def = fallbackPricingDefinition(r.Type)
+        }
+        region := normalizeRegion(r.Type, resolveRegion(r, def, vars, addrMap, clusterRegions))
+        sku := resolveSKU(r, def, vars, addrMap)
+        provider := providerFromType(r.Type)
+        byProvider[provider] = append(byProvider[provider], cache.SKURequest{
+            Service: def.ServiceName,
+            Region:  region,
+            SKU:     sku,
+        })
+    }
+
+    for provider, skus := range byProvider {
+        providerFilter = provider
+        engine := engineForResource("") // providerFilter pins the provider
+        providerFilter = ""
+        fmt.Printf("⏳ Warming %d SKU(s) for %s...\n", len(skus), provider)
+        engine.(*cache.Engine).Warm(skus, warmConcurrency)
+    }
+
+    printCacheStats()
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+    "github.com/asifkhanbk/cost-estimator/pricing"
+)
+
+// commitmentOptions maps the --commitment flag's values to the
+// PricingOptions engines use to look up non-PAYG rates. The Azure Retail
+// Prices API doesn't expose a separate Savings Plan price type the way
+// AWS does, so savings-* currently resolves to the matching reservation
+// term; azure.azurePricing is where that'd change if Azure adds one.
+var commitmentOptions = map[string]pricing.PricingOptions{
+    "payg":       pricing.PAYG,
+    "ri-1y":      {PriceType: "Reservation", ReservationTerm: "1 Year"},
+    "ri-3y":      {PriceType: "Reservation", ReservationTerm: "3 Years"},
+    "savings-1y": {PriceType: "Reservation", ReservationTerm: "1 Year"},
+    "savings-3y": {PriceType: "Reservation", ReservationTerm: "3 Years"},
+}
+
+// pricingOptionsForRun resolves the --commitment/--spot flags into the
+// PricingOptions applied to every resource this run.
+func pricingOptionsForRun() pricing.PricingOptions {
+    opts := commitmentOptions[commitment]
+    opts.Spot = spot
+    return opts
+}
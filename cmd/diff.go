@@ -0,0 +1,100 @@
+package cmd
+
+import (
+    "fmt"
+    "os"
+
+    "github.com/spf13/cobra"
+)
+
+var (
+    planOld string
+    planNew string
+)
+
+var diffCmd = &cobra.Command{
+    Use:   "diff",
+    Short: "Compare estimated cost between two Terraform JSON plans",
+    Run:   runDiff,
+}
+
+func init() {
+    rootCmd.AddCommand(diffCmd)
+    diffCmd.Flags().StringVar(&planOld, "plan-old", "", "Path to the baseline Terraform JSON plan")
+    diffCmd.Flags().StringVar(&planNew, "plan-new", "", "Path to the updated Terraform JSON plan")
+    diffCmd.Flags().StringVar(&usageFile, "usage-file", "", "Path to a usage.yml providing quantity overrides for metered resources")
+    diffCmd.Flags().StringVar(&outputFormat, "format", "table", "Output format: table, json, html, or markdown")
+    diffCmd.Flags().StringVar(&outFile, "out", "", "Write output to this file instead of stdout")
+    diffCmd.Flags().StringVar(&policyFile, "policy", "", "Path to a policies.yaml of budget/SKU guardrails; exits 2 on violation")
+}
+
+func runDiff(cmd *cobra.Command, args []string) {
+    if planOld == "" || planNew == "" {
+        fmt.Println("❌ --plan-old and --plan-new are both required")
+        os.Exit(1)
+    }
+
+    oldItems, oldTotal, err := estimatePlanFile(planOld, usageFile)
+    if err != nil {
+        fmt.Printf("❌ Failed to estimate --plan-old: %v\n", err)
+        os.Exit(1)
+    }
+    newItems, newTotal, err := estimatePlanFile(planNew, usageFile)
+    if err != nil {
+        fmt.Printf("❌ Failed to estimate --plan-new: %v\n", err)
+        os.Exit(1)
+    }
+
+    delta := diffLineItems(oldItems, newItems)
+    if err := renderLineItems(delta, newTotal-oldTotal, outputFormat, outFile); err != nil {
+        fmt.Printf("❌ Failed to render output: %v\n", err)
+        os.Exit(1)
+    }
+    fmt.Printf("Δ Total: $%.2f -> $%.2f (%+.2f)\n", oldTotal, newTotal, newTotal-oldTotal)
+    printCacheStats()
+
+    var increasePct float64
+    if oldTotal > 0 {
+        increasePct = (newTotal - oldTotal) / oldTotal * 100
+    }
+    checkPolicy(newItems, newTotal, &increasePct)
+}
+
+// diffLineItems matches old and new line items by address and returns one
+// row per resource present in either plan, annotated with ChangeType
+// ("added", "removed", "changed", "unchanged") and Delta (new - old).
+func diffLineItems(oldItems, newItems []LineItem) []LineItem {
+    oldByAddr := map[string]LineItem{}
+    for _, li := range oldItems {
+        oldByAddr[li.Address] = li
+    }
+    seen := map[string]bool{}
+
+    var out []LineItem
+    for _, n := range newItems {
+        seen[n.Address] = true
+        o, existed := oldByAddr[n.Address]
+        switch {
+        case !existed:
+            n.ChangeType = "added"
+            n.Delta = n.MonthlyCost
+        case o.MonthlyCost != n.MonthlyCost || o.SKU != n.SKU || o.Region != n.Region:
+            n.ChangeType = "changed"
+            n.Delta = n.MonthlyCost - o.MonthlyCost
+        default:
+            n.ChangeType = "unchanged"
+            n.Delta = 0
+        }
+        out = append(out, n)
+    }
+    for _, o := range oldItems {
+        if seen[o.Address] {
+            continue
+        }
+        o.ChangeType = "removed"
+        o.Delta = -o.MonthlyCost
+        o.MonthlyCost = 0
+        out = append(out, o)
+    }
+    return out
+}
@@ -1,16 +1,20 @@
 package cmd
 
 import (
-    "encoding/json"
     "fmt"
     "os"
-    "strings"
 
-    "github.com/olekukonko/tablewriter"
     "github.com/spf13/cobra"
 
-    "github.com/asifkhanbk/cost-estimator/azure"
-    "github.com/asifkhanbk/cost-estimator/pricing"
+    "github.com/asifkhanbk/cost-estimator/usage"
+)
+
+var (
+    usageFile     string
+    syncUsageFile string
+    tfDir         string
+    outputFormat  string
+    outFile       string
 )
 
 var estimateCmd = &cobra.Command{
@@ -21,97 +25,75 @@ var estimateCmd = &cobra.Command{
 
 func init() {
     rootCmd.AddCommand(estimateCmd)
+    estimateCmd.Flags().StringVar(&usageFile, "usage-file", "", "Path to a usage.yml providing quantity overrides for metered resources")
+    estimateCmd.Flags().StringVar(&syncUsageFile, "sync-usage-file", "", "Write a usage file scaffold for every metered resource in --plan, then exit")
+    estimateCmd.Flags().StringVar(&tfDir, "tf-dir", "", "Estimate a module's cost directly from its .tf files, without a terraform plan")
+    estimateCmd.Flags().StringVar(&outputFormat, "format", "table", "Output format: table, json, html, or markdown")
+    estimateCmd.Flags().StringVar(&outFile, "out", "", "Write output to this file instead of stdout")
+    estimateCmd.Flags().StringVar(&policyFile, "policy", "", "Path to a policies.yaml of budget/SKU guardrails; exits 2 on violation")
 }
 
 func runEstimate(cmd *cobra.Command, args []string) {
-    data, err := os.ReadFile(planFile)
+    if tfDir != "" {
+        if syncUsageFile != "" {
+            fmt.Println("❌ --sync-usage-file requires a Terraform plan (--plan); it isn't supported with --tf-dir")
+            os.Exit(1)
+        }
+        runEstimateFromHCL(tfDir)
+        return
+    }
+
+    if syncUsageFile != "" {
+        runSyncUsageFile()
+        return
+    }
+
+    items, total, err := estimatePlanFile(planFile, usageFile)
     if err != nil {
-        fmt.Printf("❌ Failed to read plan: %v
-", err)
+        fmt.Printf("❌ %v\n", err)
         os.Exit(1)
     }
 
-    var plan map[string]interface{}
-    if err := json.Unmarshal(data, &plan); err != nil {
-        fmt.Printf("❌ Failed to parse JSON: %v
-", err)
+    if err := renderLineItems(items, total, outputFormat, outFile); err != nil {
+        fmt.Printf("❌ Failed to render output: %v\n", err)
         os.Exit(1)
     }
+    printCacheStats()
+    checkPolicy(items, total, nil)
+}
 
-    // Extract variables and resources
-    vars := extractVariables(plan)
-    addrMap := map[string]*Resource{}
-    rootMod, ok := plan["planned_values"].(map[string]interface{})["root_module"].(map[string]interface{})
-    if !ok {
-        fmt.Println("⚠️ No root_module in plan")
+// runSyncUsageFile writes a usage file scaffold covering every metered
+// resource in --plan, without pricing anything.
+func runSyncUsageFile() {
+    resources, _, _, _, err := loadPlanResources(planFile)
+    if err != nil {
+        fmt.Printf("❌ %v\n", err)
         os.Exit(1)
     }
-    resources := extractResources(rootMod, addrMap)
-
-    // Build AKS cluster-region map
-    clusterRegions := buildClusterRegionMap(resources)
-
-    // Wire in Azure engine
-    var engine pricing.PricingEngine = azure.NewAzurePricing()
-
-    table := tablewriter.NewWriter(os.Stdout)
-    table.SetHeader([]string{"Type", "Name", "Region", "SKU / Detail", "Unit", "Usage", "Unit Cost", "Monthly Cost"})
 
-    var total float64
+    syncResources := make([]usage.Resource, 0, len(resources))
     for _, r := range resources {
-        def, found := ResourceTypePricingMap[r.Type]
-        if !found {
-            def = fallbackPricingDefinition(r.Type)
-        }
-
-        // Resolve region & SKU
-        region := resolveRegion(r, def, vars, addrMap, clusterRegions)
-        sku := resolveSKU(r, def, vars, addrMap)
-        quantity, quantityDesc := extractUsage(r, def)
-
-        // Fetch pricing
-        unitCost, unit, foundPrice := engine.FetchPrice(def.ServiceName, region, sku)
-        if r.Type == "azurerm_private_endpoint" && !foundPrice {
-            // Fallback
-            unitCost = 0.01
-            unit = "1 Hour"
-            foundPrice = true
-        }
+        syncResources = append(syncResources, usage.Resource{Address: resourceAddress(r), Type: r.Type})
+    }
+    if err := usage.WriteScaffold(syncUsageFile, usage.Sync(syncResources)); err != nil {
+        fmt.Printf("❌ Failed to write usage file scaffold: %v\n", err)
+        os.Exit(1)
+    }
+    fmt.Printf("✅ Wrote usage file scaffold to %s\n", syncUsageFile)
+}
 
-        // Compute monthly cost according to original logic
-        var monthlyCost float64
-        usageText := quantityDesc
-        lowerUnit := strings.ToLower(unit)
-        if foundPrice {
-            if strings.Contains(lowerUnit, "hour") {
-                monthlyCost = unitCost * 730 * quantity
-                if usageText == "-" {
-                    usageText = fmt.Sprintf("%.0f x 730 hours", quantity)
-                }
-            } else if strings.Contains(lowerUnit, "gb") && quantity > 0 {
-                monthlyCost = unitCost * quantity
-            } else if strings.Contains(lowerUnit, "operation") && quantity > 0 {
-                monthlyCost = unitCost * quantity
-            } else {
-                monthlyCost = unitCost * quantity
-            }
+// printCacheStats reports aggregate cache hit/miss counts across every
+// provider engine used this run and flushes the on-disk cache.
+func printCacheStats() {
+    var hits, misses int
+    for _, e := range cachedEngines() {
+        hits += e.Stats.Hits
+        misses += e.Stats.Misses
+        if err := e.Save(); err != nil {
+            fmt.Printf("⚠️ Failed to persist price cache: %v\n", err)
         }
-
-        total += monthlyCost
-        table.Append([]string{
-            r.Type,
-            r.Name,
-            region,
-            sku,
-            unit,
-            usageText,
-            fmt.Sprintf("%.6f", unitCost),
-            fmt.Sprintf("%.2f", monthlyCost),
-        })
     }
-
-    table.Render()
-    fmt.Printf("
-💰 Total Estimated Monthly Cost: $%.2f
-", total)
+    if hits+misses > 0 {
+        fmt.Printf("📦 Price cache: %d hit(s), %d miss(es)\n", hits, misses)
+    }
 }
\ No newline at end of file
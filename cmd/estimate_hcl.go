@@ -0,0 +1,120 @@
+package cmd
+
+import (
+    "fmt"
+    "os"
+    "strings"
+
+    "github.com/asifkhanbk/cost-estimator/hcl"
+    "github.com/asifkhanbk/cost-estimator/pricing"
+    "github.com/asifkhanbk/cost-estimator/usage"
+)
+
+// runEstimateFromHCL estimates a module's cost straight from its .tf
+// source, without requiring a `terraform init/plan` first. A SKU attribute
+// that couldn't be statically resolved (data source lookups, other
+// resources' computed outputs, unresolved locals) prices as "unknown SKU"
+// rather than failing the whole run. --usage-file overrides are honored
+// the same as the plan-based path, keyed by "<type>.<name>" since HCL
+// resources have no module-qualified address.
+func runEstimateFromHCL(dir string) {
+    resources, err := hcl.ParseDir(dir)
+    if err != nil {
+        fmt.Printf("❌ Failed to parse %s: %v\n", dir, err)
+        os.Exit(1)
+    }
+
+    var usageOverrides *usage.File
+    if usageFile != "" {
+        usageOverrides, err = usage.Load(usageFile)
+        if err != nil {
+            fmt.Printf("❌ Failed to load usage file: %v\n", err)
+            os.Exit(1)
+        }
+    }
+
+    opts := pricingOptionsForRun()
+
+    var items []LineItem
+    var total float64
+    for _, r := range resources {
+        def, found := pricingDefinitionFor(r.Type)
+        if !found {
+            def = fallbackPricingDefinition(r.Type)
+        }
+        engine := engineForResource(r.Type)
+
+        region := normalizeRegion(r.Type, r.String("location"))
+        sku := r.String(def.SKUField)
+        if def.SKUField != "" && sku == "" && containsAttr(r.Unknown, def.SKUField) {
+            sku = "unknown SKU"
+        }
+
+        var unitCost float64
+        var unit string
+        var foundPrice bool
+        if sku != "unknown SKU" {
+            unitCost, unit, foundPrice = pricing.FetchPriceWithOptions(engine, def.ServiceName, region, sku, opts)
+        }
+
+        var monthlyCost float64
+        usageText := "-"
+        if foundPrice {
+            if strings.Contains(strings.ToLower(unit), "hour") {
+                monthlyCost = unitCost * 730
+                usageText = "1 x 730 hours"
+            } else {
+                monthlyCost = unitCost
+            }
+        }
+
+        if hints, metered := meterHints[r.Type]; metered {
+            quantities := usage.For(r.Type, r.Type+"."+r.Name, usageOverrides)
+            for key, qty := range quantities {
+                if qty <= 0 {
+                    continue
+                }
+                hint, ok := hints[string(key)]
+                if !ok {
+                    continue
+                }
+                meterCost, meterUnit, meterFound := engine.FetchPrice(def.ServiceName, region, hint)
+                if !meterFound {
+                    continue
+                }
+                monthlyCost += meterCost * qty
+                usageText += fmt.Sprintf(", %s: %.0f %s", key, qty, meterUnit)
+            }
+        }
+
+        total += monthlyCost
+        items = append(items, LineItem{
+            Address:     r.Name,
+            Type:        r.Type,
+            Provider:    providerFromType(r.Type),
+            Region:      region,
+            Service:     def.ServiceName,
+            SKU:         sku,
+            Unit:        unit,
+            Usage:       usageText,
+            UnitCost:    unitCost,
+            MonthlyCost: monthlyCost,
+        })
+    }
+
+    if err := renderLineItems(items, total, outputFormat, outFile); err != nil {
+        fmt.Printf("❌ Failed to render output: %v\n", err)
+        os.Exit(1)
+    }
+    printCacheStats()
+    checkPolicy(items, total, nil)
+}
+
+func containsAttr(attrs []string, name string) bool {
+    for _, a := range attrs {
+        if a == name {
+            return true
+        }
+    }
+    return false
+}
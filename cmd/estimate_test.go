@@ -0,0 +1,110 @@
+package cmd
+
+import (
+    "os"
+    "path/filepath"
+    "testing"
+)
+
+// samplePlan is a minimal Terraform JSON plan covering the shapes
+// estimatePlanFile's pipeline needs to exercise: a root_module resource,
+// an AKS cluster/node-pool pair (region inherited from the cluster its
+// kubernetes_cluster_id references), and
+// a plan-level variable.
+const samplePlan = `{
+  "variables": {
+    "location": {"value": "East US"}
+  },
+  "planned_values": {
+    "root_module": {
+      "resources": [
+        {
+          "address": "azurerm_linux_virtual_machine.example",
+          "mode": "managed",
+          "type": "azurerm_linux_virtual_machine",
+          "name": "example",
+          "values": {"location": "eastus", "size": "Standard_D2s_v3"}
+        },
+        {
+          "address": "azurerm_kubernetes_cluster.aks",
+          "mode": "managed",
+          "type": "azurerm_kubernetes_cluster",
+          "name": "aks",
+          "values": {"location": "eastus", "sku_tier": "Free"}
+        },
+        {
+          "address": "azurerm_kubernetes_cluster_node_pool.pool",
+          "mode": "managed",
+          "type": "azurerm_kubernetes_cluster_node_pool",
+          "name": "pool",
+          "values": {"kubernetes_cluster_id": "azurerm_kubernetes_cluster.aks", "vm_size": "Standard_D4s_v3"}
+        }
+      ]
+    }
+  }
+}`
+
+// TestEstimatePlanFile is a smoke test: it runs the full plan-parsing,
+// resource-extraction, region/SKU-resolution and pricing pipeline
+// end-to-end against a fixture plan, offline so it never hits the network.
+// It doesn't assert on priced dollar amounts (those depend on a live Azure
+// Retail Prices lookup); it asserts the pipeline itself runs and resolves
+// every resource's type/region/SKU correctly.
+func TestEstimatePlanFile(t *testing.T) {
+    prevOffline := offline
+    offline = true
+    defer func() { offline = prevOffline }()
+
+    dir := t.TempDir()
+    planPath := filepath.Join(dir, "plan.json")
+    if err := os.WriteFile(planPath, []byte(samplePlan), 0o644); err != nil {
+        t.Fatalf("writing fixture plan: %v", err)
+    }
+
+    items, _, err := estimatePlanFile(planPath, "")
+    if err != nil {
+        t.Fatalf("estimatePlanFile returned an error: %v", err)
+    }
+    if len(items) != 3 {
+        t.Fatalf("got %d line items, want 3", len(items))
+    }
+
+    byAddress := map[string]LineItem{}
+    for _, li := range items {
+        byAddress[li.Address] = li
+    }
+
+    vm, ok := byAddress["azurerm_linux_virtual_machine.example"]
+    if !ok {
+        t.Fatal("missing line item for the virtual machine")
+    }
+    if vm.Region != "eastus" || vm.SKU != "Standard_D2s_v3" {
+        t.Errorf("vm region/sku = %q/%q, want eastus/Standard_D2s_v3", vm.Region, vm.SKU)
+    }
+
+    pool, ok := byAddress["azurerm_kubernetes_cluster_node_pool.pool"]
+    if !ok {
+        t.Fatal("missing line item for the node pool")
+    }
+    if pool.Region != "eastus" {
+        t.Errorf("node pool region = %q, want eastus inherited from its cluster", pool.Region)
+    }
+    if pool.SKU != "Standard_D4s_v3" {
+        t.Errorf("node pool sku = %q, want Standard_D4s_v3", pool.SKU)
+    }
+}
+
+// TestEstimatePlanFileMissingRootModule ensures a plan lacking
+// planned_values/root_module returns a clean error instead of panicking
+// with an interface-conversion crash.
+func TestEstimatePlanFileMissingRootModule(t *testing.T) {
+    dir := t.TempDir()
+    planPath := filepath.Join(dir, "plan.json")
+    if err := os.WriteFile(planPath, []byte(`{"variables": {}}`), 0o644); err != nil {
+        t.Fatalf("writing fixture plan: %v", err)
+    }
+
+    if _, _, err := estimatePlanFile(planPath, ""); err == nil {
+        t.Fatal("expected an error for a plan with no root_module, got nil")
+    }
+}
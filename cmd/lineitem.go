@@ -0,0 +1,134 @@
+package cmd
+
+import (
+    "fmt"
+    "strings"
+
+    "github.com/asifkhanbk/cost-estimator/pricing"
+    "github.com/asifkhanbk/cost-estimator/usage"
+)
+
+// LineItem is one priced resource row. It's the stable schema behind
+// --format json/html/markdown and the `cost-estimator diff` output, so
+// CI tooling can parse it instead of scraping the table.
+type LineItem struct {
+    Address     string  `json:"address"`
+    Type        string  `json:"type"`
+    Provider    string  `json:"provider"`
+    Region      string  `json:"region"`
+    Service     string  `json:"service"`
+    SKU         string  `json:"sku"`
+    Unit        string  `json:"unit"`
+    Usage       string  `json:"usage"`
+    UnitCost    float64 `json:"unit_cost"`
+    MonthlyCost float64 `json:"monthly_cost"`
+    // DiscountPct is the effective discount vs pay-as-you-go this line
+    // item got from --commitment/--spot, 0 when pricing PAYG.
+    DiscountPct float64 `json:"discount_pct,omitempty"`
+
+    // ChangeType and Delta are only populated in `diff` output.
+    ChangeType string  `json:"change_type,omitempty"`
+    Delta      float64 `json:"delta,omitempty"`
+}
+
+// estimatePlanFile loads a Terraform JSON plan from path and prices every
+// resource in it, returning the line items and total. It doesn't print
+// cache stats, so callers like `diff` can run this twice without
+// double-printing hit/miss counts.
+func estimatePlanFile(path, usageFilePath string) ([]LineItem, float64, error) {
+    resources, vars, addrMap, clusterRegions, err := loadPlanResources(path)
+    if err != nil {
+        return nil, 0, err
+    }
+
+    var usageOverrides *usage.File
+    if usageFilePath != "" {
+        usageOverrides, err = usage.Load(usageFilePath)
+        if err != nil {
+            return nil, 0, fmt.Errorf("loading usage file: %w", err)
+        }
+    }
+
+    opts := pricingOptionsForRun()
+
+    var items []LineItem
+    var total float64
+    for _, r := range resources {
+        def, found := pricingDefinitionFor(r.Type)
+        if !found {
+            def = fallbackPricingDefinition(r.Type)
+        }
+        engine := engineForResource(r.Type)
+
+        region := normalizeRegion(r.Type, resolveRegion(r, def, vars, addrMap, clusterRegions))
+        sku := resolveSKU(r, def, vars, addrMap)
+        quantity, quantityDesc := extractUsage(r, def)
+
+        unitCost, unit, foundPrice := pricing.FetchPriceWithOptions(engine, def.ServiceName, region, sku, opts)
+        if r.Type == "azurerm_private_endpoint" && !foundPrice {
+            unitCost = 0.01
+            unit = "1 Hour"
+            foundPrice = true
+        }
+
+        var discountPct float64
+        if foundPrice && opts != pricing.PAYG {
+            if paygCost, _, paygFound := engine.FetchPrice(def.ServiceName, region, sku); paygFound && paygCost > 0 {
+                discountPct = (paygCost - unitCost) / paygCost * 100
+            }
+        }
+
+        var monthlyCost float64
+        usageText := quantityDesc
+        lowerUnit := strings.ToLower(unit)
+        if foundPrice {
+            if strings.Contains(lowerUnit, "hour") {
+                monthlyCost = unitCost * 730 * quantity
+                if usageText == "-" {
+                    usageText = fmt.Sprintf("%.0f x 730 hours", quantity)
+                }
+            } else if strings.Contains(lowerUnit, "gb") && quantity > 0 {
+                monthlyCost = unitCost * quantity
+            } else if strings.Contains(lowerUnit, "operation") && quantity > 0 {
+                monthlyCost = unitCost * quantity
+            } else {
+                monthlyCost = unitCost * quantity
+            }
+        }
+
+        if hints, metered := meterHints[r.Type]; metered {
+            quantities := usage.For(r.Type, resourceAddress(r), usageOverrides)
+            for key, qty := range quantities {
+                if qty <= 0 {
+                    continue
+                }
+                hint, ok := hints[string(key)]
+                if !ok {
+                    continue
+                }
+                meterCost, meterUnit, meterFound := engine.FetchPrice(def.ServiceName, region, hint)
+                if !meterFound {
+                    continue
+                }
+                monthlyCost += meterCost * qty
+                usageText += fmt.Sprintf(", %s: %.0f %s", key, qty, meterUnit)
+            }
+        }
+
+        total += monthlyCost
+        items = append(items, LineItem{
+            Address:     resourceAddress(r),
+            Type:        r.Type,
+            Provider:    providerFromType(r.Type),
+            Region:      region,
+            Service:     def.ServiceName,
+            SKU:         sku,
+            Unit:        unit,
+            Usage:       usageText,
+            UnitCost:    unitCost,
+            MonthlyCost: monthlyCost,
+            DiscountPct: discountPct,
+        })
+    }
+    return items, total, nil
+}
@@ -0,0 +1,35 @@
+package cmd
+
+// meterHints maps a usage.Key on a given resource type to the Azure meter
+// name substring FetchPrice should match against, letting one metered
+// resource sum several meters into its line item (e.g. Tier 1 vs Tier 2 storage
+// operations) through the existing single FetchPrice(service, region, sku)
+// call — azurePricing already falls back to matching sku against
+// item.MeterName.
+var meterHints = map[string]map[string]string{
+    "azurerm_storage_account": {
+        "storage_gb":                "Data Stored",
+        "monthly_tier_1_operations": "Tier 1 Operations",
+        "monthly_tier_2_operations": "Tier 2 Operations",
+    },
+    "azurerm_application_gateway": {
+        "capacity_units":    "Capacity Unit",
+        "data_processed_gb": "Data Processed",
+    },
+    "azurerm_log_analytics_workspace": {
+        "ingestion_gb": "Data Ingestion",
+    },
+    "azurerm_data_transfer": {
+        "monthly_data_transfer_gb": "Data Transfer Out",
+    },
+}
+
+// resourceAddress returns the Terraform address usage files key overrides
+// by, falling back to "<type>.<name>" for resources parsed without a plan
+// (e.g. --tf-dir) where no module-qualified address exists.
+func resourceAddress(r *Resource) string {
+    if r.Address != "" {
+        return r.Address
+    }
+    return r.Type + "." + r.Name
+}
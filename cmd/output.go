@@ -0,0 +1,150 @@
+package cmd
+
+import (
+    "bytes"
+    "encoding/json"
+    "fmt"
+    "html"
+    "io"
+    "os"
+
+    "github.com/olekukonko/tablewriter"
+)
+
+// planResult is the stable JSON document written by --format json. In
+// `diff` output Total holds the new-minus-old total delta.
+type planResult struct {
+    LineItems []LineItem `json:"line_items"`
+    Total     float64    `json:"total_monthly_cost"`
+}
+
+// renderLineItems writes items (and total) to outPath (stdout if empty) in
+// the requested format.
+func renderLineItems(items []LineItem, total float64, format, outPath string) error {
+    var buf bytes.Buffer
+    switch format {
+    case "", "table":
+        renderTable(&buf, items, total)
+    case "json":
+        if err := renderJSON(&buf, items, total); err != nil {
+            return err
+        }
+    case "html":
+        renderHTML(&buf, items, total)
+    case "markdown":
+        renderMarkdown(&buf, items, total)
+    default:
+        return fmt.Errorf("unknown --format %q (want table, json, html, or markdown)", format)
+    }
+
+    var out io.Writer = os.Stdout
+    if outPath != "" {
+        f, err := os.Create(outPath)
+        if err != nil {
+            return err
+        }
+        defer f.Close()
+        out = f
+    }
+    _, err := out.Write(buf.Bytes())
+    return err
+}
+
+func renderTable(w io.Writer, items []LineItem, total float64) {
+    diffMode := isDiff(items)
+    discounted := hasDiscount(items)
+
+    table := tablewriter.NewWriter(w)
+    header := []string{"Type", "Name", "Region", "SKU / Detail", "Unit", "Usage", "Unit Cost", "Monthly Cost"}
+    if discounted {
+        header = append(header, "vs PAYG")
+    }
+    if diffMode {
+        header = append(header, "Change", "Delta")
+    }
+    table.SetHeader(header)
+    for _, li := range items {
+        row := []string{
+            li.Type,
+            li.Address,
+            li.Region,
+            li.SKU,
+            li.Unit,
+            li.Usage,
+            fmt.Sprintf("%.6f", li.UnitCost),
+            fmt.Sprintf("%.2f", li.MonthlyCost),
+        }
+        if discounted {
+            row = append(row, fmt.Sprintf("%.1f%%", li.DiscountPct))
+        }
+        if diffMode {
+            row = append(row, li.ChangeType, fmt.Sprintf("%+.2f", li.Delta))
+        }
+        table.Append(row)
+    }
+    table.Render()
+    if diffMode {
+        fmt.Fprintf(w, "\n💰 Total Monthly Cost Delta: $%+.2f\n", total)
+        return
+    }
+    fmt.Fprintf(w, "\n💰 Total Estimated Monthly Cost: $%.2f\n", total)
+}
+
+// isDiff reports whether items came from `cost-estimator diff`, which sets
+// ChangeType on every row.
+func isDiff(items []LineItem) bool {
+    for _, li := range items {
+        if li.ChangeType != "" {
+            return true
+        }
+    }
+    return false
+}
+
+// hasDiscount reports whether any item priced at a non-PAYG discount, so
+// the "vs PAYG" column only shows up when --commitment/--spot is active.
+func hasDiscount(items []LineItem) bool {
+    for _, li := range items {
+        if li.DiscountPct != 0 {
+            return true
+        }
+    }
+    return false
+}
+
+func renderJSON(w io.Writer, items []LineItem, total float64) error {
+    enc := json.NewEncoder(w)
+    enc.SetIndent("", "  ")
+    return enc.Encode(planResult{LineItems: items, Total: total})
+}
+
+func renderMarkdown(w io.Writer, items []LineItem, total float64) {
+    fmt.Fprintln(w, "| Type | Address | Region | SKU | Unit | Usage | Unit Cost | Monthly Cost |")
+    fmt.Fprintln(w, "|---|---|---|---|---|---|---|---|")
+    for _, li := range items {
+        fmt.Fprintf(w, "| %s | %s | %s | %s | %s | %s | %.6f | %.2f |\n",
+            li.Type, li.Address, li.Region, li.SKU, li.Unit, li.Usage, li.UnitCost, li.MonthlyCost)
+    }
+    if isDiff(items) {
+        fmt.Fprintf(w, "\n**Total Monthly Cost Delta: $%+.2f**\n", total)
+        return
+    }
+    fmt.Fprintf(w, "\n**Total Estimated Monthly Cost: $%.2f**\n", total)
+}
+
+func renderHTML(w io.Writer, items []LineItem, total float64) {
+    fmt.Fprintln(w, "<table>")
+    fmt.Fprintln(w, "<tr><th>Type</th><th>Address</th><th>Region</th><th>SKU</th><th>Unit</th><th>Usage</th><th>Unit Cost</th><th>Monthly Cost</th></tr>")
+    for _, li := range items {
+        fmt.Fprintf(w, "<tr><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%s</td><td>%.6f</td><td>%.2f</td></tr>\n",
+            html.EscapeString(li.Type), html.EscapeString(li.Address), html.EscapeString(li.Region),
+            html.EscapeString(li.SKU), html.EscapeString(li.Unit), html.EscapeString(li.Usage),
+            li.UnitCost, li.MonthlyCost)
+    }
+    fmt.Fprintln(w, "</table>")
+    if isDiff(items) {
+        fmt.Fprintf(w, "<p><strong>Total Monthly Cost Delta: $%+.2f</strong></p>\n", total)
+        return
+    }
+    fmt.Fprintf(w, "<p><strong>Total Estimated Monthly Cost: $%.2f</strong></p>\n", total)
+}
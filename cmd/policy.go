@@ -0,0 +1,54 @@
+package cmd
+
+import (
+    "fmt"
+    "os"
+
+    "github.com/olekukonko/tablewriter"
+
+    "github.com/asifkhanbk/cost-estimator/policy"
+)
+
+var policyFile string
+
+// checkPolicy loads --policy (a no-op if unset), evaluates it against
+// items/total (and, in diff mode, increasePct), prints a violations table
+// when any rule fails, and exits the process with code 2 so CI can block
+// the plan.
+func checkPolicy(items []LineItem, total float64, increasePct *float64) {
+    if policyFile == "" {
+        return
+    }
+
+    p, err := policy.Load(policyFile)
+    if err != nil {
+        fmt.Printf("❌ Failed to load policy file: %v\n", err)
+        os.Exit(1)
+    }
+
+    resources := make([]policy.Resource, 0, len(items))
+    for _, li := range items {
+        resources = append(resources, policy.Resource{
+            Type:        li.Type,
+            Service:     li.Service,
+            SKU:         li.SKU,
+            Region:      li.Region,
+            MonthlyCost: li.MonthlyCost,
+        })
+    }
+
+    violations := policy.Evaluate(p, resources, total, increasePct)
+    if len(violations) == 0 {
+        fmt.Println("✅ Policy checks passed")
+        return
+    }
+
+    table := tablewriter.NewWriter(os.Stdout)
+    table.SetHeader([]string{"Rule", "Violation"})
+    for _, v := range violations {
+        table.Append([]string{v.Rule, v.Message})
+    }
+    table.Render()
+    fmt.Printf("❌ %d policy violation(s)\n", len(violations))
+    os.Exit(2)
+}
@@ -6,8 +6,8 @@ type PricingInfo struct {
 	SKUField    string
 }
 
-// ResourcePricingMap maps Terraform resource types to Azure service name and SKU field
-var ResourcePricingMap = map[string]PricingInfo{
+// ResourceTypePricingMap maps Terraform resource types to Azure service name and SKU field
+var ResourceTypePricingMap = map[string]PricingInfo{
 	// Compute
 	"azurerm_linux_virtual_machine":        {ServiceName: "Virtual Machines", SKUField: "size"},
 	"azurerm_windows_virtual_machine":      {ServiceName: "Virtual Machines", SKUField: "size"},
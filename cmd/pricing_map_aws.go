@@ -0,0 +1,37 @@
+package cmd
+
+// AWSResourceTypePricingMap maps aws_* Terraform resource types to the AWS
+// Price List ServiceCode (via the "ServiceName" field, reused from
+// PricingInfo) and the resource field to use as SKU/instance type.
+var AWSResourceTypePricingMap = map[string]PricingInfo{
+    // Compute
+    "aws_instance":             {ServiceName: "EC2", SKUField: "instance_type"},
+    "aws_launch_template":      {ServiceName: "EC2", SKUField: "instance_type"},
+    "aws_autoscaling_group":    {ServiceName: "EC2", SKUField: ""},
+
+    // Kubernetes
+    "aws_eks_cluster":          {ServiceName: "EKS", SKUField: ""},
+    // instance_types is a list (instance_types = ["t3.medium"]); resolveSKU
+    // and hcl.Resource.String both price off its first element.
+    "aws_eks_node_group":       {ServiceName: "EC2", SKUField: "instance_types"},
+
+    // Storage
+    "aws_ebs_volume":           {ServiceName: "EBS", SKUField: "type"},
+    "aws_s3_bucket":            {ServiceName: "S3", SKUField: ""},
+
+    // Databases
+    "aws_db_instance":          {ServiceName: "RDS", SKUField: "instance_class"},
+    "aws_rds_cluster_instance": {ServiceName: "RDS", SKUField: "instance_class"},
+
+    // Networking
+    "aws_lb":                   {ServiceName: "ELB", SKUField: "load_balancer_type"},
+    "aws_elb":                  {ServiceName: "ELB", SKUField: ""},
+    "aws_nat_gateway":          {ServiceName: "NATGateway", SKUField: ""},
+    "aws_cloudfront_distribution": {ServiceName: "CloudFront", SKUField: ""},
+
+    // Serverless
+    "aws_lambda_function":      {ServiceName: "Lambda", SKUField: "memory_size"},
+
+    // Bandwidth
+    "aws_data_transfer":        {ServiceName: "DataTransfer", SKUField: ""},
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+    "fmt"
+    "strings"
+    "time"
+
+    "github.com/asifkhanbk/cost-estimator/aws"
+    "github.com/asifkhanbk/cost-estimator/azure"
+    "github.com/asifkhanbk/cost-estimator/cache"
+    "github.com/asifkhanbk/cost-estimator/pricing"
+)
+
+// providerEngines caches one cache-wrapped PricingEngine per cloud provider
+// for the lifetime of the run, so a mixed-provider plan doesn't rebuild an
+// HTTP client (or reload the on-disk cache) per resource.
+var providerEngines = map[string]*cache.Engine{}
+
+// cachedEngines returns every engine built so far this run, for printing
+// aggregate hit/miss stats once estimation finishes.
+func cachedEngines() []*cache.Engine {
+    engines := make([]*cache.Engine, 0, len(providerEngines))
+    for _, e := range providerEngines {
+        engines = append(engines, e)
+    }
+    return engines
+}
+
+// providerFromType infers the cloud provider from a Terraform resource
+// type's prefix (e.g. "aws_instance" -> "aws", "azurerm_lb" -> "azurerm").
+func providerFromType(resourceType string) string {
+    if strings.HasPrefix(resourceType, "aws_") {
+        return "aws"
+    }
+    return "azurerm"
+}
+
+// engineForResource returns the PricingEngine responsible for pricing a
+// resource of resourceType.
+// The --provider flag, when set, pins every resource to that provider's
+// engine; otherwise the provider is inferred per-resource from its type
+// prefix so a single plan can mix azurerm_* and aws_* resources.
+func engineForResource(resourceType string) pricing.PricingEngine {
+    provider := providerFilter
+    if provider == "" {
+        provider = providerFromType(resourceType)
+    }
+    if e, ok := providerEngines[provider]; ok {
+        return e
+    }
+
+    var inner pricing.PricingEngine
+    switch provider {
+    case "aws":
+        inner = aws.NewAWSPricing()
+    default:
+        inner = azure.NewAzurePricing()
+    }
+
+    e, err := newCachedEngine(inner)
+    if err != nil {
+        // Caching is a perf optimization, not a correctness requirement;
+        // fall back to an uncached engine rather than failing the run.
+        fmt.Printf("⚠️ Cache unavailable, falling back to uncached lookups: %v\n", err)
+        e, _ = cache.New(inner, "", 0, false)
+    }
+    providerEngines[provider] = e
+    return e
+}
+
+// newCachedEngine wraps inner with the on-disk/LRU cache described by the
+// --cache-ttl and --offline flags.
+func newCachedEngine(inner pricing.PricingEngine) (*cache.Engine, error) {
+    dir, err := cache.DefaultDir()
+    if err != nil {
+        return nil, err
+    }
+    ttl, err := time.ParseDuration(cacheTTL)
+    if err != nil {
+        return nil, fmt.Errorf("invalid --cache-ttl %q: %w", cacheTTL, err)
+    }
+    return cache.New(inner, dir, ttl, offline)
+}
+
+// pricingDefinitionFor looks up the PricingInfo for a resource type in the
+// map belonging to its cloud provider.
+func pricingDefinitionFor(resourceType string) (PricingInfo, bool) {
+    if providerFromType(resourceType) == "aws" {
+        def, found := AWSResourceTypePricingMap[resourceType]
+        return def, found
+    }
+    def, found := ResourceTypePricingMap[resourceType]
+    return def, found
+}
+
+// azureRegionAliases maps the friendly Azure region display names that
+// sometimes show up in Terraform variables (or usage files) to the short
+// names the Retail Prices API expects.
+var azureRegionAliases = map[string]string{
+    "East US":     "eastus",
+    "West US":     "westus",
+    "West Europe": "westeurope",
+}
+
+// awsRegionAliases maps AWS console display names to the region codes the
+// Price List Query API expects.
+var awsRegionAliases = map[string]string{
+    "US East (N. Virginia)": "us-east-1",
+    "US West (Oregon)":      "us-west-2",
+    "EU (Ireland)":          "eu-west-1",
+}
+
+// normalizeRegion rewrites a region value into the naming convention the
+// resource's cloud provider expects, passing it through unchanged if it's
+// already in that form.
+func normalizeRegion(resourceType, region string) string {
+    if providerFromType(resourceType) == "aws" {
+        if alias, ok := awsRegionAliases[region]; ok {
+            return alias
+        }
+        return region
+    }
+    if alias, ok := azureRegionAliases[region]; ok {
+        return alias
+    }
+    return region
+}
@@ -0,0 +1,216 @@
+package cmd
+
+import (
+    "encoding/json"
+    "fmt"
+    "os"
+    "strconv"
+)
+
+// loadPlanResources reads and parses a Terraform JSON plan from path and
+// extracts everything the pricing pipeline needs from it. It's the single
+// safe (every assertion comma-ok'd) parsing path for planned_values.root_module,
+// shared by estimatePlanFile, runSyncUsageFile and runCacheWarm so a plan
+// missing that key returns a clean error instead of panicking in three
+// different places.
+func loadPlanResources(path string) (resources []*Resource, vars map[string]interface{}, addrMap map[string]*Resource, clusterRegions map[string]string, err error) {
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return nil, nil, nil, nil, fmt.Errorf("reading plan: %w", err)
+    }
+
+    var plan map[string]interface{}
+    if err := json.Unmarshal(data, &plan); err != nil {
+        return nil, nil, nil, nil, fmt.Errorf("parsing plan JSON: %w", err)
+    }
+
+    vars = extractVariables(plan)
+
+    plannedValues, ok := plan["planned_values"].(map[string]interface{})
+    if !ok {
+        return nil, nil, nil, nil, fmt.Errorf("no root_module in plan")
+    }
+    rootMod, ok := plannedValues["root_module"].(map[string]interface{})
+    if !ok {
+        return nil, nil, nil, nil, fmt.Errorf("no root_module in plan")
+    }
+
+    addrMap = map[string]*Resource{}
+    resources = extractResources(rootMod, addrMap)
+    clusterRegions = buildClusterRegionMap(resources)
+    return resources, vars, addrMap, clusterRegions, nil
+}
+
+// Resource is a single resource instance out of a Terraform JSON plan's
+// planned_values.root_module (and any nested child_modules), keyed by its
+// full Terraform address.
+type Resource struct {
+    Address    string
+    Type       string
+    Name       string
+    Attributes map[string]interface{}
+}
+
+// extractVariables returns the plan's top-level input variables as a
+// simple name -> value map, unwrapping the plan JSON's
+// `{"variables": {"name": {"value": ...}}}` shape.
+func extractVariables(plan map[string]interface{}) map[string]interface{} {
+    vars := map[string]interface{}{}
+    raw, ok := plan["variables"].(map[string]interface{})
+    if !ok {
+        return vars
+    }
+    for name, v := range raw {
+        entry, ok := v.(map[string]interface{})
+        if !ok {
+            continue
+        }
+        vars[name] = entry["value"]
+    }
+    return vars
+}
+
+// extractResources walks a planned_values.root_module object (and its
+// child_modules, recursively) collecting every managed resource into a
+// flat list, and indexes each one by address in addrMap.
+func extractResources(module map[string]interface{}, addrMap map[string]*Resource) []*Resource {
+    var resources []*Resource
+
+    if raw, ok := module["resources"].([]interface{}); ok {
+        for _, item := range raw {
+            entry, ok := item.(map[string]interface{})
+            if !ok {
+                continue
+            }
+            address, _ := entry["address"].(string)
+            rtype, _ := entry["type"].(string)
+            name, _ := entry["name"].(string)
+            values, _ := entry["values"].(map[string]interface{})
+
+            r := &Resource{Address: address, Type: rtype, Name: name, Attributes: values}
+            resources = append(resources, r)
+            if address != "" {
+                addrMap[address] = r
+            }
+        }
+    }
+
+    if children, ok := module["child_modules"].([]interface{}); ok {
+        for _, c := range children {
+            child, ok := c.(map[string]interface{})
+            if !ok {
+                continue
+            }
+            resources = append(resources, extractResources(child, addrMap)...)
+        }
+    }
+
+    return resources
+}
+
+// buildClusterRegionMap maps each azurerm_kubernetes_cluster's address to
+// its region, so node pools (whose own `values` don't carry a location)
+// can inherit their parent cluster's region.
+func buildClusterRegionMap(resources []*Resource) map[string]string {
+    clusterRegions := map[string]string{}
+    for _, r := range resources {
+        if r.Type != "azurerm_kubernetes_cluster" {
+            continue
+        }
+        if loc, ok := r.Attributes["location"].(string); ok && loc != "" {
+            clusterRegions[r.Address] = loc
+        }
+    }
+    return clusterRegions
+}
+
+// resolveRegion resolves a resource's region: its own `location`
+// attribute first, then (for AKS node pools) the region of whichever
+// cluster it belongs to, then a same-named input variable, in that order.
+func resolveRegion(r *Resource, def PricingInfo, vars map[string]interface{}, addrMap map[string]*Resource, clusterRegions map[string]string) string {
+    if loc, ok := r.Attributes["location"].(string); ok && loc != "" {
+        return loc
+    }
+
+    if r.Type == "azurerm_kubernetes_cluster_node_pool" {
+        if clusterID, ok := r.Attributes["kubernetes_cluster_id"].(string); ok {
+            if cluster, ok := addrMap[clusterID]; ok {
+                if loc, ok := cluster.Attributes["location"].(string); ok && loc != "" {
+                    return loc
+                }
+            }
+        }
+        // Fall back to the only AKS cluster in the plan when the cluster
+        // reference above couldn't be resolved to a concrete address.
+        if len(clusterRegions) == 1 {
+            for _, region := range clusterRegions {
+                return region
+            }
+        }
+    }
+
+    if loc, ok := vars["location"].(string); ok && loc != "" {
+        return loc
+    }
+    return ""
+}
+
+// resolveSKU reads def.SKUField off r, stringifying scalar values and
+// falling back to the first element when the field is a list (e.g.
+// aws_eks_node_group.instance_types) rather than resolving empty.
+func resolveSKU(r *Resource, def PricingInfo, vars map[string]interface{}, addrMap map[string]*Resource) string {
+    if def.SKUField == "" {
+        return ""
+    }
+    if sku, ok := attrString(r.Attributes[def.SKUField]); ok && sku != "" {
+        return sku
+    }
+    if sku, ok := attrString(vars[def.SKUField]); ok {
+        return sku
+    }
+    return ""
+}
+
+// attrString coerces a plan attribute value into a string SKU: strings
+// pass through, numbers are formatted, and a non-empty list's first
+// element is used (lists are how Terraform represents multi-value fields
+// like aws_eks_node_group.instance_types, which still only admit one
+// price lookup here).
+func attrString(v interface{}) (string, bool) {
+    switch val := v.(type) {
+    case string:
+        return val, true
+    case float64:
+        return formatFloat(val), true
+    case []interface{}:
+        if len(val) == 0 {
+            return "", false
+        }
+        return attrString(val[0])
+    default:
+        return "", false
+    }
+}
+
+func formatFloat(f float64) string {
+    if f == float64(int64(f)) {
+        return strconv.FormatInt(int64(f), 10)
+    }
+    return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// extractUsage derives the quantity to multiply a unit cost by. The plan
+// JSON doesn't carry ongoing usage (bandwidth, operations, ...) so this
+// defaults to a single unit; metered resources get their real quantities
+// from the --usage-file overrides layered on top in estimatePlanFile.
+func extractUsage(r *Resource, def PricingInfo) (float64, string) {
+    return 1, "-"
+}
+
+// fallbackPricingDefinition is used when a resource type has no entry in
+// ResourceTypePricingMap/AWSResourceTypePricingMap: an empty ServiceName
+// means FetchPrice will find nothing, pricing the resource at $0 rather
+// than guessing.
+func fallbackPricingDefinition(resourceType string) PricingInfo {
+    return PricingInfo{}
+}
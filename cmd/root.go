@@ -10,6 +10,10 @@ import (
 var (
 	planFile       string
 	providerFilter string
+	cacheTTL       string
+	offline        bool
+	commitment     string
+	spot           bool
 )
 
 // rootCmd is the main CLI entrypoint
@@ -30,5 +34,9 @@ func Execute() {
 func init() {
 	rootCmd.PersistentFlags().StringVarP(&planFile, "plan", "p", "", "Path to Terraform JSON plan")
 	rootCmd.PersistentFlags().StringVar(&providerFilter, "provider", "", "Filter by provider prefix (e.g., azurerm)")
+	rootCmd.PersistentFlags().StringVar(&cacheTTL, "cache-ttl", "24h", "How long cached prices stay valid before a refetch")
+	rootCmd.PersistentFlags().BoolVar(&offline, "offline", false, "Serve prices from cache only; a cache miss leaves that resource unpriced rather than failing the run")
+	rootCmd.PersistentFlags().StringVar(&commitment, "commitment", "payg", "Pricing mode: payg, ri-1y, ri-3y, savings-1y, or savings-3y")
+	rootCmd.PersistentFlags().BoolVar(&spot, "spot", false, "Price eligible resources at spot/preemptible rates")
 	// No need to add estimateCmd here, as estimateCmd.go calls AddCommand
 }
\ No newline at end of file
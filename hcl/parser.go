@@ -0,0 +1,290 @@
+// hcl/parser.go
+package hcl
+
+import (
+    "fmt"
+    "os"
+    "path/filepath"
+    "strconv"
+
+    hclpkg "github.com/hashicorp/hcl/v2"
+    "github.com/hashicorp/hcl/v2/hclparse"
+    "github.com/hashicorp/hcl/v2/hclsyntax"
+    "github.com/zclconf/go-cty/cty"
+    "github.com/zclconf/go-cty/cty/function"
+)
+
+// Resource is a synthetic resource parsed directly out of .tf source,
+// shaped to be easy to feed into the same pricing pipeline a Terraform
+// JSON plan produces: a type, an address-unique name, and its raw
+// attribute values (as far as they could be statically resolved).
+type Resource struct {
+    Type       string
+    Name       string
+    Attributes map[string]cty.Value
+    // Unknown lists attribute names whose value couldn't be resolved
+    // (e.g. it depends on a data source or a resource's computed output),
+    // so callers can render "unknown SKU" instead of silently pricing $0.
+    Unknown []string
+}
+
+// String returns an attribute's value as a string, or "" if absent or
+// unresolved. A list/tuple attribute (e.g. aws_eks_node_group.instance_types)
+// resolves to its first element rather than "", since every SKU field this
+// package prices is ultimately a single value.
+func (r Resource) String(attr string) string {
+    v, ok := r.Attributes[attr]
+    if !ok || !v.IsKnown() || v.IsNull() {
+        return ""
+    }
+    if v.Type().IsListType() || v.Type().IsTupleType() || v.Type().IsSetType() {
+        if v.LengthInt() == 0 {
+            return ""
+        }
+        v = v.AsValueSlice()[0]
+        if !v.IsKnown() || v.IsNull() || v.Type() != cty.String {
+            return ""
+        }
+        return v.AsString()
+    }
+    if v.Type() != cty.String {
+        return ""
+    }
+    return v.AsString()
+}
+
+// ParseDir parses every *.tf file directly under dir (no terraform
+// init/plan required), resolving variable defaults, locals and simple
+// interpolations, and expanding count/for_each into one Resource per
+// instance. Expressions that can't be resolved statically are recorded on
+// Resource.Unknown rather than failing the parse.
+func ParseDir(dir string) ([]Resource, error) {
+    entries, err := os.ReadDir(dir)
+    if err != nil {
+        return nil, fmt.Errorf("reading tf-dir: %w", err)
+    }
+
+    parser := hclparse.NewParser()
+    var files []*hclsyntax.Body
+    for _, entry := range entries {
+        if entry.IsDir() || filepath.Ext(entry.Name()) != ".tf" {
+            continue
+        }
+        path := filepath.Join(dir, entry.Name())
+        f, diags := parser.ParseHCLFile(path)
+        if diags.HasErrors() {
+            return nil, fmt.Errorf("parsing %s: %w", path, diags)
+        }
+        body, ok := f.Body.(*hclsyntax.Body)
+        if !ok {
+            continue
+        }
+        files = append(files, body)
+    }
+
+    ctx := baseEvalContext()
+    loadVariablesAndLocals(files, ctx)
+
+    var resources []Resource
+    for _, body := range files {
+        for _, block := range body.Blocks {
+            if block.Type != "resource" || len(block.Labels) != 2 {
+                continue
+            }
+            resources = append(resources, expandResource(block, ctx)...)
+        }
+    }
+    return resources, nil
+}
+
+// expandResource evaluates a single `resource "type" "name" {}` block,
+// expanding it into multiple Resource instances when it declares count or
+// for_each.
+func expandResource(block *hclsyntax.Block, ctx *hclpkg.EvalContext) []Resource {
+    resourceType, name := block.Labels[0], block.Labels[1]
+
+    instances := []string{name}
+    if countAttr, ok := block.Body.Attributes["count"]; ok {
+        if v, diags := countAttr.Expr.Value(ctx); !diags.HasErrors() && v.Type() == cty.Number {
+            n, _ := v.AsBigFloat().Int64()
+            instances = make([]string, n)
+            for i := range instances {
+                instances[i] = fmt.Sprintf("%s[%d]", name, i)
+            }
+        }
+    } else if feAttr, ok := block.Body.Attributes["for_each"]; ok {
+        if v, diags := feAttr.Expr.Value(ctx); !diags.HasErrors() && (v.CanIterateElements()) {
+            instances = nil
+            it := v.ElementIterator()
+            for it.Next() {
+                k, _ := it.Element()
+                instances = append(instances, fmt.Sprintf("%s[%q]", name, keyString(k)))
+            }
+        }
+    }
+
+    resources := make([]Resource, 0, len(instances))
+    for _, instanceName := range instances {
+        r := Resource{Type: resourceType, Name: instanceName, Attributes: map[string]cty.Value{}}
+        for attrName, attr := range block.Body.Attributes {
+            if attrName == "count" || attrName == "for_each" {
+                continue
+            }
+            v, diags := attr.Expr.Value(ctx)
+            if diags.HasErrors() || !v.IsWhollyKnown() {
+                r.Unknown = append(r.Unknown, attrName)
+                continue
+            }
+            r.Attributes[attrName] = v
+        }
+        resources = append(resources, r)
+    }
+    return resources
+}
+
+func keyString(v cty.Value) string {
+    if v.Type() == cty.String {
+        return v.AsString()
+    }
+    return v.GoString()
+}
+
+// loadVariablesAndLocals seeds ctx.Variables["var"] and ctx.Variables["local"]
+// from every `variable` block's default and `locals` block in files. Locals
+// that reference other locals/vars are resolved with a couple of passes,
+// which is enough for the simple `${}` interpolations typical Azure
+// modules use; anything left unresolved just stays absent from the map and
+// any attribute referencing it is reported via Resource.Unknown.
+func loadVariablesAndLocals(files []*hclsyntax.Body, ctx *hclpkg.EvalContext) {
+    vars := map[string]cty.Value{}
+    for _, body := range files {
+        for _, block := range body.Blocks {
+            if block.Type != "variable" || len(block.Labels) != 1 {
+                continue
+            }
+            if def, ok := block.Body.Attributes["default"]; ok {
+                if v, diags := def.Expr.Value(ctx); !diags.HasErrors() {
+                    vars[block.Labels[0]] = v
+                }
+            }
+        }
+    }
+    ctx.Variables["var"] = cty.ObjectVal(vars)
+
+    locals := map[string]cty.Value{}
+    for pass := 0; pass < 3; pass++ {
+        ctx.Variables["local"] = cty.ObjectVal(locals)
+        for _, body := range files {
+            for _, block := range body.Blocks {
+                if block.Type != "locals" {
+                    continue
+                }
+                for name, attr := range block.Body.Attributes {
+                    if v, diags := attr.Expr.Value(ctx); !diags.HasErrors() {
+                        locals[name] = v
+                    }
+                }
+            }
+        }
+    }
+    ctx.Variables["local"] = cty.ObjectVal(locals)
+}
+
+// baseEvalContext wires up the handful of HCL functions typical Azure
+// modules lean on when composing names and SKUs.
+func baseEvalContext() *hclpkg.EvalContext {
+    return &hclpkg.EvalContext{
+        Variables: map[string]cty.Value{},
+        Functions: map[string]function.Function{
+            "lookup":   lookupFunc,
+            "merge":    mergeFunc,
+            "coalesce": coalesceFunc,
+            "format":   formatFunc,
+        },
+    }
+}
+
+var lookupFunc = function.New(&function.Spec{
+    Params: []function.Parameter{
+        {Name: "map", Type: cty.DynamicPseudoType},
+        {Name: "key", Type: cty.String},
+    },
+    VarParam: &function.Parameter{Name: "default", Type: cty.DynamicPseudoType},
+    Type:     function.StaticReturnType(cty.DynamicPseudoType),
+    Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+        m := args[0]
+        key := args[1].AsString()
+        if m.Type().IsObjectType() && m.Type().HasAttribute(key) {
+            return m.GetAttr(key), nil
+        }
+        if m.Type().IsMapType() {
+            it := m.ElementIterator()
+            for it.Next() {
+                k, v := it.Element()
+                if k.AsString() == key {
+                    return v, nil
+                }
+            }
+        }
+        if len(args) > 2 {
+            return args[2], nil
+        }
+        return cty.NilVal, fmt.Errorf("lookup: key %q not found", key)
+    },
+})
+
+var mergeFunc = function.New(&function.Spec{
+    VarParam: &function.Parameter{Name: "maps", Type: cty.DynamicPseudoType},
+    Type:     function.StaticReturnType(cty.DynamicPseudoType),
+    Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+        out := map[string]cty.Value{}
+        for _, m := range args {
+            if !m.Type().IsObjectType() && !m.Type().IsMapType() {
+                continue
+            }
+            it := m.ElementIterator()
+            for it.Next() {
+                k, v := it.Element()
+                out[keyString(k)] = v
+            }
+        }
+        return cty.ObjectVal(out), nil
+    },
+})
+
+var coalesceFunc = function.New(&function.Spec{
+    VarParam: &function.Parameter{Name: "values", Type: cty.DynamicPseudoType},
+    Type:     function.StaticReturnType(cty.DynamicPseudoType),
+    Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+        for _, v := range args {
+            if v.IsKnown() && !v.IsNull() {
+                return v, nil
+            }
+        }
+        return cty.NilVal, fmt.Errorf("coalesce: all arguments are null")
+    },
+})
+
+var formatFunc = function.New(&function.Spec{
+    Params: []function.Parameter{
+        {Name: "spec", Type: cty.String},
+    },
+    VarParam: &function.Parameter{Name: "args", Type: cty.DynamicPseudoType},
+    Type:     function.StaticReturnType(cty.String),
+    Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+        spec := args[0].AsString()
+        rest := make([]interface{}, len(args)-1)
+        for i, v := range args[1:] {
+            switch {
+            case v.Type() == cty.String:
+                rest[i] = v.AsString()
+            case v.Type() == cty.Number:
+                f, _ := v.AsBigFloat().Float64()
+                rest[i] = strconv.FormatFloat(f, 'f', -1, 64)
+            default:
+                rest[i] = v.GoString()
+            }
+        }
+        return cty.StringVal(fmt.Sprintf(spec, rest...)), nil
+    },
+})
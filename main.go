@@ -0,0 +1,9 @@
+// cost-estimator estimates the monthly cost of a Terraform plan (or a
+// module's raw .tf source) against live Azure/AWS pricing.
+package main
+
+import "github.com/asifkhanbk/cost-estimator/cmd"
+
+func main() {
+    cmd.Execute()
+}
@@ -0,0 +1,117 @@
+// policy/expr.go
+package policy
+
+import (
+    "fmt"
+    "regexp"
+    "strconv"
+    "strings"
+)
+
+// sumExprPattern matches `sum(monthly_cost where <predicate>) OP N`, where
+// <predicate> is one or more field comparisons joined by "and". Comparison
+// operators on the outer expression: <, <=, >, >=, ==.
+var sumExprPattern = regexp.MustCompile(
+    `^\s*sum\(monthly_cost where (.+)\)\s*(<=|>=|==|<|>)\s*([0-9.]+)\s*$`,
+)
+
+// predicatePattern matches one `field == "value"` or `field != "value"`
+// clause. field is one of type, service, sku, region.
+var predicatePattern = regexp.MustCompile(
+    `^\s*(type|service|sku|region)\s*(==|!=)\s*"([^"]*)"\s*$`,
+)
+
+// evalExpression evaluates the small `sum(monthly_cost where <predicate>) OP N`
+// expression language against resources, returning whether the plan
+// satisfies it.
+//
+// <predicate> supports `==`/`!=` comparisons against a resource's type,
+// service, sku, or region, ANDed together (e.g. `service == "EC2" and
+// region != "us-east-1"`). There's no OR, parentheses, or comparison
+// against monthly_cost itself in a predicate — this covers guardrails
+// scoped to a specific type/service/sku/region, not a general expression
+// language.
+func evalExpression(expr string, resources []Resource) (bool, error) {
+    m := sumExprPattern.FindStringSubmatch(expr)
+    if m == nil {
+        return false, fmt.Errorf("unsupported expression syntax %q", expr)
+    }
+    rawPredicate, op, rawLimit := m[1], m[2], m[3]
+
+    predicates, err := parsePredicates(rawPredicate)
+    if err != nil {
+        return false, fmt.Errorf("unsupported expression syntax %q: %w", expr, err)
+    }
+
+    limit, err := strconv.ParseFloat(rawLimit, 64)
+    if err != nil {
+        return false, fmt.Errorf("invalid limit %q: %w", rawLimit, err)
+    }
+
+    var sum float64
+    for _, res := range resources {
+        if matchesAll(res, predicates) {
+            sum += res.MonthlyCost
+        }
+    }
+
+    switch op {
+    case "<":
+        return sum < limit, nil
+    case "<=":
+        return sum <= limit, nil
+    case ">":
+        return sum > limit, nil
+    case ">=":
+        return sum >= limit, nil
+    case "==":
+        return sum == limit, nil
+    default:
+        return false, fmt.Errorf("unsupported operator %q", strings.TrimSpace(op))
+    }
+}
+
+type predicate struct {
+    field string
+    op    string
+    value string
+}
+
+func parsePredicates(raw string) ([]predicate, error) {
+    var predicates []predicate
+    for _, clause := range strings.Split(raw, " and ") {
+        m := predicatePattern.FindStringSubmatch(clause)
+        if m == nil {
+            return nil, fmt.Errorf("unsupported predicate %q", strings.TrimSpace(clause))
+        }
+        predicates = append(predicates, predicate{field: m[1], op: m[2], value: m[3]})
+    }
+    return predicates, nil
+}
+
+func matchesAll(res Resource, predicates []predicate) bool {
+    for _, p := range predicates {
+        var actual string
+        switch p.field {
+        case "type":
+            actual = res.Type
+        case "service":
+            actual = res.Service
+        case "sku":
+            actual = res.SKU
+        case "region":
+            actual = res.Region
+        }
+        switch p.op {
+        case "==":
+            if actual != p.value {
+                return false
+            }
+        case "!=":
+            if actual == p.value {
+                return false
+            }
+        }
+    }
+    return true
+}
@@ -0,0 +1,150 @@
+// policy/policy.go
+package policy
+
+import (
+    "fmt"
+    "os"
+
+    "gopkg.in/yaml.v3"
+)
+
+// Rule is a single guardrail evaluated against an estimation result. Only
+// the fields relevant to a rule's check need to be set; zero/nil fields
+// are ignored.
+type Rule struct {
+    Name string `yaml:"name"`
+
+    // MaxMonthlyUSD caps the plan's total monthly cost.
+    MaxMonthlyUSD *float64 `yaml:"max_monthly_usd"`
+
+    // MaxResourceTypeUSD caps the combined monthly cost of every resource
+    // of ResourceType.
+    ResourceType       string   `yaml:"resource_type"`
+    MaxResourceTypeUSD *float64 `yaml:"max_resource_type_usd"`
+
+    // MaxIncreasePct caps the percentage increase of the plan's total
+    // monthly cost in `diff` mode.
+    MaxIncreasePct *float64 `yaml:"max_increase_pct"`
+
+    // SKU allow/deny lists, scoped to Service when set.
+    Service         string   `yaml:"service"`
+    SKUAllowlist    []string `yaml:"sku_allowlist"`
+    SKUDenylist     []string `yaml:"sku_denylist"`
+    RegionAllowlist []string `yaml:"region_allowlist"`
+
+    // Expression is a small `sum(monthly_cost where type == "...") < N`
+    // style check; see expr.go.
+    Expression string `yaml:"expression"`
+}
+
+// Policy is the top-level policies.yaml document.
+type Policy struct {
+    Rules []Rule `yaml:"rules"`
+}
+
+// Load reads and parses a policies.yaml file.
+func Load(path string) (*Policy, error) {
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return nil, fmt.Errorf("reading policy file: %w", err)
+    }
+    var p Policy
+    if err := yaml.Unmarshal(data, &p); err != nil {
+        return nil, fmt.Errorf("parsing policy file: %w", err)
+    }
+    return &p, nil
+}
+
+// Resource is the minimal view of a priced resource a Rule needs. Callers
+// adapt their own line-item type into this to avoid a dependency on cmd.
+type Resource struct {
+    Type        string
+    Service     string
+    SKU         string
+    Region      string
+    MonthlyCost float64
+}
+
+// Violation is one failed Rule.
+type Violation struct {
+    Rule    string
+    Message string
+}
+
+// Evaluate checks every rule in p against resources and total (and, in diff
+// mode, increasePct over the old total), returning one Violation per
+// failed rule.
+func Evaluate(p *Policy, resources []Resource, total float64, increasePct *float64) []Violation {
+    var violations []Violation
+    for _, rule := range p.Rules {
+        if v, failed := rule.check(resources, total, increasePct); failed {
+            violations = append(violations, v)
+        }
+    }
+    return violations
+}
+
+func (r Rule) check(resources []Resource, total float64, increasePct *float64) (Violation, bool) {
+    name := r.Name
+    if name == "" {
+        name = "policy rule"
+    }
+
+    if r.MaxMonthlyUSD != nil && total > *r.MaxMonthlyUSD {
+        return Violation{Rule: name, Message: fmt.Sprintf("total monthly cost $%.2f exceeds cap $%.2f", total, *r.MaxMonthlyUSD)}, true
+    }
+
+    if r.MaxResourceTypeUSD != nil && r.ResourceType != "" {
+        var sum float64
+        for _, res := range resources {
+            if res.Type == r.ResourceType {
+                sum += res.MonthlyCost
+            }
+        }
+        if sum > *r.MaxResourceTypeUSD {
+            return Violation{Rule: name, Message: fmt.Sprintf("%s monthly cost $%.2f exceeds cap $%.2f", r.ResourceType, sum, *r.MaxResourceTypeUSD)}, true
+        }
+    }
+
+    if r.MaxIncreasePct != nil && increasePct != nil && *increasePct > *r.MaxIncreasePct {
+        return Violation{Rule: name, Message: fmt.Sprintf("cost increase %.1f%% exceeds cap %.1f%%", *increasePct, *r.MaxIncreasePct)}, true
+    }
+
+    if len(r.SKUAllowlist) > 0 || len(r.SKUDenylist) > 0 || len(r.RegionAllowlist) > 0 {
+        for _, res := range resources {
+            if r.Service != "" && res.Service != r.Service {
+                continue
+            }
+            if len(r.SKUAllowlist) > 0 && !contains(r.SKUAllowlist, res.SKU) {
+                return Violation{Rule: name, Message: fmt.Sprintf("%s uses disallowed SKU %q", res.Type, res.SKU)}, true
+            }
+            if contains(r.SKUDenylist, res.SKU) {
+                return Violation{Rule: name, Message: fmt.Sprintf("%s uses denied SKU %q", res.Type, res.SKU)}, true
+            }
+            if len(r.RegionAllowlist) > 0 && !contains(r.RegionAllowlist, res.Region) {
+                return Violation{Rule: name, Message: fmt.Sprintf("%s is in disallowed region %q", res.Type, res.Region)}, true
+            }
+        }
+    }
+
+    if r.Expression != "" {
+        ok, err := evalExpression(r.Expression, resources)
+        if err != nil {
+            return Violation{Rule: name, Message: fmt.Sprintf("invalid expression: %v", err)}, true
+        }
+        if !ok {
+            return Violation{Rule: name, Message: fmt.Sprintf("expression %q failed", r.Expression)}, true
+        }
+    }
+
+    return Violation{}, false
+}
+
+func contains(list []string, s string) bool {
+    for _, v := range list {
+        if v == s {
+            return true
+        }
+    }
+    return false
+}
@@ -5,3 +5,40 @@ type PricingEngine interface {
     // FetchPrice returns (unitCost, unitOfMeasure, found).
     FetchPrice(service, region, sku string) (float64, string, bool)
 }
+
+// PricingOptions customizes a price lookup beyond plain pay-as-you-go
+// on-demand pricing.
+type PricingOptions struct {
+    // PriceType is "Consumption" (pay-as-you-go, the default), "Reservation",
+    // or "DevTestConsumption".
+    PriceType string
+    // ReservationTerm is "1 Year" or "3 Years", and only applies when
+    // PriceType is "Reservation".
+    ReservationTerm string
+    // Spot requests spot/preemptible pricing for the SKU.
+    Spot bool
+}
+
+// PAYG is the zero-value PricingOptions: plain pay-as-you-go pricing.
+var PAYG = PricingOptions{}
+
+// OptionsPricingEngine is implemented by engines that can price
+// reservations, savings plans, and spot capacity alongside plain on-demand
+// rates.
+type OptionsPricingEngine interface {
+    PricingEngine
+    // FetchPriceWithOptions returns (unitCost, unitOfMeasure, found) for
+    // sku priced under opts.
+    FetchPriceWithOptions(service, region, sku string, opts PricingOptions) (float64, string, bool)
+}
+
+// FetchPriceWithOptions calls engine.FetchPriceWithOptions when engine
+// implements OptionsPricingEngine, and otherwise falls back to plain
+// FetchPrice (i.e. always pay-as-you-go) for engines that don't support
+// non-PAYG pricing yet.
+func FetchPriceWithOptions(engine PricingEngine, service, region, sku string, opts PricingOptions) (float64, string, bool) {
+    if oe, ok := engine.(OptionsPricingEngine); ok {
+        return oe.FetchPriceWithOptions(service, region, sku, opts)
+    }
+    return engine.FetchPrice(service, region, sku)
+}
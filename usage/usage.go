@@ -0,0 +1,166 @@
+// usage/usage.go
+package usage
+
+import (
+    "fmt"
+    "os"
+    "strings"
+
+    "gopkg.in/yaml.v3"
+)
+
+// Key is the name of a single usage quantity a resource type can be
+// estimated with, e.g. "storage_gb" or "monthly_tier_1_operations".
+type Key string
+
+// Schema declares the usage keys a resource type accepts and the default
+// value assumed for each when no override is given.
+type Schema map[Key]float64
+
+// ResourceSchemas is the Infracost-style usage schema for every resource
+// type that has metered (rather than purely SKU-based) pricing.
+var ResourceSchemas = map[string]Schema{
+    "azurerm_data_transfer": {
+        "monthly_data_transfer_gb": 0,
+    },
+    "azurerm_storage_account": {
+        "storage_gb":               0,
+        "monthly_tier_1_operations": 0,
+        "monthly_tier_2_operations": 0,
+    },
+    "azurerm_application_gateway": {
+        "capacity_units":    0,
+        "data_processed_gb": 0,
+    },
+    "azurerm_log_analytics_workspace": {
+        "ingestion_gb": 0,
+    },
+}
+
+// File is the parsed contents of a --usage-file: per-resource-address
+// overrides take precedence over per-type overrides, which take
+// precedence over the schema default.
+type File struct {
+    // ResourceUsage maps a resource address (e.g.
+    // "azurerm_storage_account.foo") to its usage key overrides.
+    ResourceUsage map[string]map[string]float64 `yaml:"resource_usage"`
+    // TypeUsage maps a resource type (e.g. "azurerm_storage_account") to
+    // usage key overrides applied to every resource of that type; a
+    // ResourceUsage entry for the resource overrides them key by key.
+    TypeUsage map[string]map[string]float64 `yaml:"type_usage"`
+}
+
+// Load reads and validates a usage file. Keys that aren't declared in
+// ResourceSchemas for the relevant resource type are rejected so typos
+// fail loudly instead of silently estimating as zero.
+func Load(path string) (*File, error) {
+    data, err := os.ReadFile(path)
+    if err != nil {
+        return nil, fmt.Errorf("reading usage file: %w", err)
+    }
+    var f File
+    if err := yaml.Unmarshal(data, &f); err != nil {
+        return nil, fmt.Errorf("parsing usage file: %w", err)
+    }
+    if err := f.validate(); err != nil {
+        return nil, err
+    }
+    return &f, nil
+}
+
+func (f *File) validate() error {
+    for addr, keys := range f.ResourceUsage {
+        resourceType := typeFromAddress(addr)
+        if err := validateKeys(resourceType, keys); err != nil {
+            return fmt.Errorf("resource_usage[%s]: %w", addr, err)
+        }
+    }
+    for resourceType, keys := range f.TypeUsage {
+        if err := validateKeys(resourceType, keys); err != nil {
+            return fmt.Errorf("type_usage[%s]: %w", resourceType, err)
+        }
+    }
+    return nil
+}
+
+func validateKeys(resourceType string, keys map[string]float64) error {
+    schema, ok := ResourceSchemas[resourceType]
+    if !ok {
+        return fmt.Errorf("%s has no usage schema", resourceType)
+    }
+    for k := range keys {
+        if _, ok := schema[Key(k)]; !ok {
+            return fmt.Errorf("unknown usage key %q for %s", k, resourceType)
+        }
+    }
+    return nil
+}
+
+// typeFromAddress derives a resource's type from its Terraform address,
+// e.g. "azurerm_storage_account.foo" -> "azurerm_storage_account", skipping
+// any leading "module.<name>." segments of a module-qualified address.
+func typeFromAddress(address string) string {
+    parts := strings.Split(address, ".")
+    for len(parts) > 2 && parts[0] == "module" {
+        parts = parts[2:]
+    }
+    return parts[0]
+}
+
+// For resolves the effective usage quantities for a resource, merging the
+// schema defaults with any type_usage and resource_usage overrides.
+func For(resourceType, address string, f *File) map[Key]float64 {
+    quantities := map[Key]float64{}
+    for k, v := range ResourceSchemas[resourceType] {
+        quantities[k] = v
+    }
+    if f == nil {
+        return quantities
+    }
+    if overrides, ok := f.TypeUsage[resourceType]; ok {
+        for k, v := range overrides {
+            quantities[Key(k)] = v
+        }
+    }
+    if overrides, ok := f.ResourceUsage[address]; ok {
+        for k, v := range overrides {
+            quantities[Key(k)] = v
+        }
+    }
+    return quantities
+}
+
+// Sync builds a scaffold File containing every overridable key (at its
+// schema default) for each of the given resources, so a user can fill in
+// real numbers without having to remember the key names.
+func Sync(resources []Resource) *File {
+    f := &File{ResourceUsage: map[string]map[string]float64{}}
+    for _, r := range resources {
+        schema, ok := ResourceSchemas[r.Type]
+        if !ok {
+            continue
+        }
+        keys := map[string]float64{}
+        for k, v := range schema {
+            keys[string(k)] = v
+        }
+        f.ResourceUsage[r.Address] = keys
+    }
+    return f
+}
+
+// WriteScaffold marshals a scaffold File to path as YAML.
+func WriteScaffold(path string, f *File) error {
+    data, err := yaml.Marshal(f)
+    if err != nil {
+        return err
+    }
+    return os.WriteFile(path, data, 0o644)
+}
+
+// Resource is the minimal resource description Sync needs; callers adapt
+// their own resource type into this to avoid a dependency on cmd.
+type Resource struct {
+    Address string
+    Type    string
+}